@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiresources
+
+import (
+	"testing"
+
+	apidiscoveryv2 "k8s.io/apimachinery/pkg/apis/apidiscovery/v2"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+func TestParseAggregatedDiscoveryBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantNil  bool
+		wantLen  int // expected len(Items) when wantNil is false
+	}{
+		{
+			name:    "valid APIGroupDiscoveryList",
+			body:    `{"kind":"APIGroupDiscoveryList","apiVersion":"apidiscovery.k8s.io/v2","items":[{"metadata":{"name":"apps"}}]}`,
+			wantNil: false,
+			wantLen: 1,
+		},
+		{
+			name:    "malformed JSON",
+			body:    `not json`,
+			wantNil: true,
+		},
+		{
+			name:    "legacy APIGroupList shape (server ignored the Accept header)",
+			body:    `{"kind":"APIGroupList","apiVersion":"v1","groups":[{"name":"apps"}]}`,
+			wantNil: true,
+		},
+		{
+			name:    "right kind, wrong apiVersion",
+			body:    `{"kind":"APIGroupDiscoveryList","apiVersion":"apidiscovery.k8s.io/v2beta1","items":[]}`,
+			wantNil: true,
+		},
+		{
+			name:    "empty body",
+			body:    ``,
+			wantNil: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := parseAggregatedDiscoveryBody([]byte(tc.body))
+			if tc.wantNil {
+				if got != nil {
+					t.Fatalf("parseAggregatedDiscoveryBody(%q) = %+v, want nil", tc.body, got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("parseAggregatedDiscoveryBody(%q) = nil, want non-nil", tc.body)
+			}
+			if len(got.Items) != tc.wantLen {
+				t.Errorf("len(Items) = %d, want %d", len(got.Items), tc.wantLen)
+			}
+		})
+	}
+}
+
+func TestTranslateAggregatedGroup(t *testing.T) {
+	o := NewAPIResourceOptions(genericiooptions.IOStreams{})
+
+	group := apidiscoveryv2.APIGroupDiscovery{
+		Versions: []apidiscoveryv2.APIVersionDiscovery{
+			{
+				Version: "v1",
+				Resources: []apidiscoveryv2.APIResourceDiscovery{
+					{
+						Resource:         "deployments",
+						SingularResource: "deployment",
+						Scope:            apidiscoveryv2.ScopeNamespace,
+						Verbs:            []string{"get", "list"},
+						ShortNames:       []string{"deploy"},
+						Subresources: []apidiscoveryv2.APISubresourceDiscovery{
+							{Subresource: "status"},
+							{Subresource: "scale"},
+						},
+					},
+				},
+			},
+			{
+				// A second, non-preferred version; its resources must not
+				// appear in the result.
+				Version: "v1beta1",
+				Resources: []apidiscoveryv2.APIResourceDiscovery{
+					{Resource: "deployments", Verbs: []string{"get"}},
+				},
+			},
+		},
+	}
+	group.Name = "apps"
+
+	groupResources, apiResources := o.translateAggregatedGroup(group)
+	if len(groupResources) != 1 || len(apiResources) != 1 {
+		t.Fatalf("got %d groupResources and %d apiResources, want 1 and 1", len(groupResources), len(apiResources))
+	}
+
+	gr := groupResources[0]
+	if gr.APIGroupVersion != "apps/v1" {
+		t.Errorf("APIGroupVersion = %q, want %q", gr.APIGroupVersion, "apps/v1")
+	}
+	if gr.APIResource.Name != "deployments" {
+		t.Errorf("APIResource.Name = %q, want %q", gr.APIResource.Name, "deployments")
+	}
+	wantSubresources := []string{"status", "scale"}
+	if !stringSlicesEqualUnordered(gr.Subresources, wantSubresources) {
+		t.Errorf("Subresources = %v, want %v", gr.Subresources, wantSubresources)
+	}
+}
+
+func TestTranslateAggregatedGroupNoVersions(t *testing.T) {
+	o := NewAPIResourceOptions(genericiooptions.IOStreams{})
+
+	groupResources, apiResources := o.translateAggregatedGroup(apidiscoveryv2.APIGroupDiscovery{})
+	if groupResources != nil || apiResources != nil {
+		t.Fatalf("got %v, %v, want nil, nil for a group with no versions", groupResources, apiResources)
+	}
+}
@@ -21,17 +21,21 @@ import (
 	"io"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	clientcmd "k8s.io/client-go/tools/clientcmd"
 	"k8s.io/kubectl/pkg/cmd/get"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/scheme"
@@ -39,6 +43,19 @@ import (
 	"k8s.io/kubectl/pkg/util/templates"
 )
 
+// multiClusterViewMerge, multiClusterViewMatrix and multiClusterViewDiff are
+// the supported values for --multi-cluster-view.
+const (
+	multiClusterViewMerge  = "merge"
+	multiClusterViewMatrix = "matrix"
+	multiClusterViewDiff   = "diff"
+
+	// maxContextWorkers bounds how many contexts are queried concurrently so
+	// that pointing --all-contexts at a large kubeconfig doesn't open an
+	// unbounded number of connections at once.
+	maxContextWorkers = 8
+)
+
 var (
 	apiresourcesExample = templates.Examples(`
 		# Print the supported API resources
@@ -70,10 +87,34 @@ type APIResourceOptions struct {
 	Cached     bool
 	Categories []string
 
-	groupChanged bool
-	nsChanged    bool
+	// Contexts is the set of kubeconfig contexts to query, in addition to
+	// (or instead of) the current context. AllContexts expands to every
+	// context defined in the kubeconfig.
+	Contexts         []string
+	AllContexts      bool
+	MultiClusterView string
+
+	// Deprecated filters to resources whose OpenAPI v3 schema is (or
+	// isn't) marked deprecated. Only applied if the --deprecated flag was
+	// set; see deprecatedChanged.
+	Deprecated bool
+	// DescriptionContains filters to resources whose OpenAPI v3
+	// description contains this substring.
+	DescriptionContains string
 
-	discoveryClient discovery.CachedDiscoveryInterface
+	groupChanged      bool
+	nsChanged         bool
+	deprecatedChanged bool
+
+	restClientGetter genericclioptions.RESTClientGetter
+	discoveryClient  discovery.CachedDiscoveryInterface
+
+	// discoveryClients holds one discovery client per selected context,
+	// keyed by context name. It is only populated when multiple contexts
+	// are in play; the single-context path continues to use
+	// discoveryClient above.
+	discoveryClients map[string]discovery.CachedDiscoveryInterface
+	contextOrder     []string
 
 	genericiooptions.IOStreams
 	PrintFlags *PrintFlags
@@ -85,12 +126,118 @@ type groupResource struct {
 	APIGroup        string
 	APIGroupVersion string
 	APIResource     metav1.APIResource
+
+	// Contexts lists the kubeconfig contexts that expose this GVK, and the
+	// served version seen in each. It is only populated in multi-cluster
+	// mode; single-context runs leave it empty.
+	Contexts map[string]string
+
+	// The remaining fields are populated from the server's OpenAPI v3
+	// documents, when available; see enrichWithOpenAPIV3.
+	Description        string
+	Deprecated         bool
+	DeprecationVersion string
+	FieldCount         int
+
+	// Subresources lists the subresources served alongside this resource
+	// (e.g. "status", "scale"). It is only populated when discovery went
+	// through the aggregated discovery endpoint; see
+	// fetchAggregatedGroupResources.
+	Subresources []string
+}
+
+// APIResource is a flattened, printable view of a groupResource: it
+// synthesizes the APIGroup/APIGroupVersion fields that live alongside
+// metav1.APIResource in a groupResource so custom-columns, jsonpath and
+// go-template output can address them directly (e.g.
+// -o custom-columns=NAME:.name,GROUP:.apiGroup).
+type APIResource struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Name               string   `json:"name"`
+	ShortNames         []string `json:"shortNames,omitempty"`
+	APIVersion         string   `json:"apiVersion"`
+	APIGroup           string   `json:"apiGroup"`
+	APIGroupVersion    string   `json:"apiGroupVersion"`
+	Namespaced         bool     `json:"namespaced"`
+	Kind               string   `json:"kind"`
+	Verbs              []string `json:"verbs,omitempty"`
+	Categories         []string `json:"categories,omitempty"`
+	Description        string   `json:"description,omitempty"`
+	Deprecated         bool     `json:"deprecated,omitempty"`
+	DeprecationVersion string   `json:"deprecationVersion,omitempty"`
+	FieldCount         int      `json:"fieldCount,omitempty"`
+	Subresources       []string `json:"subresources,omitempty"`
+}
+
+// DeepCopyObject implements runtime.Object so APIResource can flow through
+// the printer chain.
+func (in *APIResource) DeepCopyObject() runtime.Object {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.ShortNames = append([]string(nil), in.ShortNames...)
+	out.Verbs = append([]string(nil), in.Verbs...)
+	out.Categories = append([]string(nil), in.Categories...)
+	out.Subresources = append([]string(nil), in.Subresources...)
+	return &out
+}
+
+// toAPIResource converts a groupResource into its synthesized, printable
+// form.
+func (r groupResource) toAPIResource() *APIResource {
+	return &APIResource{
+		Name:               r.APIResource.Name,
+		ShortNames:         r.APIResource.ShortNames,
+		APIVersion:         r.APIGroupVersion,
+		APIGroup:           r.APIGroup,
+		APIGroupVersion:    r.APIGroupVersion,
+		Namespaced:         r.APIResource.Namespaced,
+		Kind:               r.APIResource.Kind,
+		Verbs:              r.APIResource.Verbs,
+		Categories:         r.APIResource.Categories,
+		Description:        r.Description,
+		Deprecated:         r.Deprecated,
+		DeprecationVersion: r.DeprecationVersion,
+		FieldCount:         r.FieldCount,
+		Subresources:       r.Subresources,
+	}
+}
+
+// MultiClusterAPIResourceList wraps one APIResourceList per kubeconfig
+// context so that JSON/YAML consumers can tell which cluster each set of
+// resources came from.
+type MultiClusterAPIResourceList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Contexts maps a kubeconfig context name to the resources discovered
+	// for that context.
+	Contexts map[string]*metav1.APIResourceList `json:"contexts"`
+}
+
+// DeepCopyObject implements runtime.Object so MultiClusterAPIResourceList
+// can flow through the same printer chain as any other API object.
+func (in *MultiClusterAPIResourceList) DeepCopyObject() runtime.Object {
+	if in == nil {
+		return nil
+	}
+	out := &MultiClusterAPIResourceList{TypeMeta: in.TypeMeta}
+	if in.Contexts != nil {
+		out.Contexts = make(map[string]*metav1.APIResourceList, len(in.Contexts))
+		for ctx, list := range in.Contexts {
+			out.Contexts[ctx] = list.DeepCopy()
+		}
+	}
+	return out
 }
 
 type PrintFlags struct {
 	JSONYamlPrintFlags *genericclioptions.JSONYamlPrintFlags
 	NamePrintFlags     *genericclioptions.NamePrintFlags
 	HumanReadableFlags *get.HumanPrintFlags
+	CustomColumnsFlags *get.CustomColumnsPrintFlags
+	TemplateFlags      *genericclioptions.KubeTemplatePrintFlags
 
 	NoHeaders    *bool
 	OutputFormat *string
@@ -106,6 +253,8 @@ func NewPrintFlags() *PrintFlags {
 		JSONYamlPrintFlags: genericclioptions.NewJSONYamlPrintFlags(),
 		NamePrintFlags:     genericclioptions.NewNamePrintFlags(""),
 		HumanReadableFlags: APIResourcesHumanReadableFlags(),
+		CustomColumnsFlags: get.NewCustomColumnsPrintFlags(),
+		TemplateFlags:      genericclioptions.NewKubeTemplatePrintFlags(),
 	}
 }
 
@@ -121,6 +270,8 @@ func (f *PrintFlags) AllowedFormats() []string {
 	ret := []string{}
 	ret = append(ret, f.JSONYamlPrintFlags.AllowedFormats()...)
 	ret = append(ret, f.NamePrintFlags.AllowedFormats()...)
+	ret = append(ret, f.TemplateFlags.AllowedFormats()...)
+	ret = append(ret, f.CustomColumnsFlags.AllowedFormats()...)
 	ret = append(ret, f.HumanReadableFlags.AllowedFormats()...)
 	return ret
 }
@@ -129,6 +280,8 @@ func (f *PrintFlags) AddFlags(cmd *cobra.Command) {
 	f.JSONYamlPrintFlags.AddFlags(cmd)
 	f.HumanReadableFlags.AddFlags(cmd)
 	f.NamePrintFlags.AddFlags(cmd)
+	f.TemplateFlags.AddFlags(cmd)
+	f.CustomColumnsFlags.AddFlags(cmd)
 
 	if f.OutputFormat != nil {
 		cmd.Flags().StringVarP(f.OutputFormat, "output", "o", *f.OutputFormat, fmt.Sprintf("Output format. One of: (%s).", strings.Join(f.AllowedFormats(), ", ")))
@@ -149,6 +302,11 @@ func (f *PrintFlags) ToPrinter() (printers.ResourcePrinter, error) {
 		noHeaders = *f.NoHeaders
 	}
 	f.HumanReadableFlags.NoHeaders = noHeaders
+	f.CustomColumnsFlags.NoHeaders = noHeaders
+
+	if p, err := f.TemplateFlags.ToPrinter(outputFormat); !genericclioptions.IsNoCompatiblePrinterError(err) {
+		return p, err
+	}
 
 	if p, err := f.JSONYamlPrintFlags.ToPrinter(outputFormat); !genericclioptions.IsNoCompatiblePrinterError(err) {
 		return p, err
@@ -158,6 +316,10 @@ func (f *PrintFlags) ToPrinter() (printers.ResourcePrinter, error) {
 		return p, err
 	}
 
+	if p, err := f.CustomColumnsFlags.ToPrinter(outputFormat); !genericclioptions.IsNoCompatiblePrinterError(err) {
+		return p, err
+	}
+
 	if p, err := f.NamePrintFlags.ToPrinter(outputFormat); !genericclioptions.IsNoCompatiblePrinterError(err) {
 		return p, err
 	}
@@ -171,6 +333,14 @@ func NewAPIResourceOptions(ioStreams genericiooptions.IOStreams) *APIResourceOpt
 		IOStreams:  ioStreams,
 		Namespaced: true,
 		PrintFlags: NewPrintFlags(),
+		// MultiClusterView is only meaningful once more than one context
+		// is selected, but Validate rejects any value outside
+		// {merge, matrix, diff} unconditionally. The top-level
+		// api-resources command overwrites this with its own
+		// --multi-cluster-view flag default (also "merge"); snapshot and
+		// diff never register that flag, so without this default their
+		// embedded APIResourceOptions would fail Validate on every run.
+		MultiClusterView: multiClusterViewMerge,
 	}
 }
 
@@ -198,6 +368,15 @@ func NewCmdAPIResources(restClientGetter genericclioptions.RESTClientGetter, ioS
 	//cmd.Flags().StringVar(&o.SortBy, "sort-by", o.SortBy, "If non-empty, sort list of resources using specified field. The field can be either 'name' or 'kind'.")
 	cmd.Flags().BoolVar(&o.Cached, "cached", o.Cached, "Use the cached list of resources if available.")
 	cmd.Flags().StringSliceVar(&o.Categories, "categories", o.Categories, "Limit to resources that belong to the specified categories.")
+	cmd.Flags().StringSliceVar(&o.Contexts, "context", o.Contexts, "Kubeconfig context to query. May be repeated to query multiple clusters at once.")
+	cmd.Flags().BoolVar(&o.AllContexts, "all-contexts", o.AllContexts, "Query every context in the kubeconfig. Overrides --context.")
+	cmd.Flags().StringVar(&o.MultiClusterView, "multi-cluster-view", multiClusterViewMerge, "When more than one context is selected, how to present results. One of: merge, matrix, diff.")
+	cmd.Flags().BoolVar(&o.Deprecated, "deprecated", o.Deprecated, "Limit to resources that are (true) or aren't (false) marked deprecated in the server's OpenAPI schema.")
+	cmd.Flags().StringVar(&o.DescriptionContains, "description-contains", o.DescriptionContains, "Limit to resources whose OpenAPI description contains the given substring.")
+
+	cmd.AddCommand(NewCmdAPIResourcesSnapshot(restClientGetter, ioStreams))
+	cmd.AddCommand(NewCmdAPIResourcesDiff(restClientGetter, ioStreams))
+
 	return cmd
 }
 
@@ -209,6 +388,10 @@ func (o *APIResourceOptions) Validate() error {
 			return fmt.Errorf("--sort-by accepts only name or kind")
 		}
 	}
+	supportedMultiClusterViews := sets.New[string](multiClusterViewMerge, multiClusterViewMatrix, multiClusterViewDiff)
+	if !supportedMultiClusterViews.Has(o.MultiClusterView) {
+		return fmt.Errorf("--multi-cluster-view accepts only %s", strings.Join(sets.List(supportedMultiClusterViews), ", "))
+	}
 	return nil
 }
 
@@ -218,14 +401,38 @@ func (o *APIResourceOptions) Complete(restClientGetter genericclioptions.RESTCli
 		return cmdutil.UsageErrorf(cmd, "unexpected arguments: %v", args)
 	}
 
+	o.restClientGetter = restClientGetter
+
 	discoveryClient, err := restClientGetter.ToDiscoveryClient()
 	if err != nil {
 		return err
 	}
 	o.discoveryClient = discoveryClient
 
+	if o.AllContexts {
+		rawConfig, err := restClientGetter.ToRawKubeConfigLoader().RawConfig()
+		if err != nil {
+			return err
+		}
+		o.Contexts = nil
+		for name := range rawConfig.Contexts {
+			o.Contexts = append(o.Contexts, name)
+		}
+		sort.Strings(o.Contexts)
+	}
+
+	if len(o.Contexts) > 0 {
+		discoveryClients, contextOrder, err := loadDiscoveryClientsForContexts(restClientGetter, o.Contexts)
+		if err != nil {
+			return err
+		}
+		o.discoveryClients = discoveryClients
+		o.contextOrder = contextOrder
+	}
+
 	o.groupChanged = cmd.Flags().Changed("api-group")
 	o.nsChanged = cmd.Flags().Changed("namespaced")
+	o.deprecatedChanged = cmd.Flags().Changed("deprecated")
 
 	var printer printers.ResourcePrinter
 	if o.PrintFlags.OutputFormat != nil {
@@ -258,18 +465,66 @@ func (o *APIResourceOptions) Complete(restClientGetter genericclioptions.RESTCli
 	return nil
 }
 
-// RunAPIResources does the work
-func (o *APIResourceOptions) RunAPIResources() error {
-	w := printers.GetNewTabWriter(o.Out)
-	defer w.Flush()
+// matchesFilters reports whether resource, served under gv, satisfies the
+// group/namespaced/verb/category filters shared by every discovery mode.
+func (o *APIResourceOptions) matchesFilters(gv schema.GroupVersion, resource metav1.APIResource) bool {
+	if len(resource.Verbs) == 0 {
+		return false
+	}
+	// filter apiGroup
+	if o.groupChanged && o.APIGroup != gv.Group {
+		return false
+	}
+	// filter namespaced
+	if o.nsChanged && o.Namespaced != resource.Namespaced {
+		return false
+	}
+	// filter to resources that support the specified verbs
+	if len(o.Verbs) > 0 && !sets.New[string](resource.Verbs...).HasAll(o.Verbs...) {
+		return false
+	}
+	// filter to resources that belong to the specified categories
+	if len(o.Categories) > 0 && !sets.New[string](resource.Categories...).HasAll(o.Categories...) {
+		return false
+	}
+	return true
+}
 
+// fetchGroupResources queries a single discovery client, preferring the
+// aggregated discovery endpoint (a single round trip) and falling back to
+// the legacy per-group discovery path when the server doesn't support it.
+func (o *APIResourceOptions) fetchGroupResources(discoveryClient discovery.CachedDiscoveryInterface) ([]groupResource, []*metav1.APIResourceList, []error) {
 	if !o.Cached {
 		// Always request fresh data from the server
-		o.discoveryClient.Invalidate()
+		discoveryClient.Invalidate()
+	}
+
+	if resources, allResources, ok := o.fetchAggregatedGroupResources(discoveryClient); ok {
+		return resources, allResources, nil
+	}
+
+	return o.fetchLegacyGroupResources(discoveryClient)
+}
+
+// applyOpenAPIPostProcessing enriches resources with OpenAPI v3 metadata
+// and, if --deprecated or --description-contains was set, drops resources
+// that don't match. Both discovery paths need this after building their
+// respective groupResource/allResources slices.
+func (o *APIResourceOptions) applyOpenAPIPostProcessing(discoveryClient discovery.CachedDiscoveryInterface, resources []groupResource, allResources []*metav1.APIResourceList) ([]groupResource, []*metav1.APIResourceList) {
+	enrichWithOpenAPIV3(discoveryClient, resources)
+	if o.deprecatedChanged || o.DescriptionContains != "" {
+		resources, allResources = filterByOpenAPIMetadata(resources, allResources, o)
 	}
+	return resources, allResources
+}
 
+// fetchLegacyGroupResources discovers resources the pre-1.27 way: one
+// request per API group via ServerPreferredResources. It's the fallback
+// used when the server doesn't understand the aggregated discovery Accept
+// header.
+func (o *APIResourceOptions) fetchLegacyGroupResources(discoveryClient discovery.CachedDiscoveryInterface) ([]groupResource, []*metav1.APIResourceList, []error) {
 	errs := []error{}
-	lists, err := o.discoveryClient.ServerPreferredResources()
+	lists, err := discoveryClient.ServerPreferredResources()
 	if err != nil {
 		errs = append(errs, err)
 	}
@@ -294,23 +549,7 @@ func (o *APIResourceOptions) RunAPIResources() error {
 		}
 		var apiResources []metav1.APIResource
 		for _, resource := range list.APIResources {
-			if len(resource.Verbs) == 0 {
-				continue
-			}
-			// filter apiGroup
-			if o.groupChanged && o.APIGroup != gv.Group {
-				continue
-			}
-			// filter namespaced
-			if o.nsChanged && o.Namespaced != resource.Namespaced {
-				continue
-			}
-			// filter to resources that support the specified verbs
-			if len(o.Verbs) > 0 && !sets.New[string](resource.Verbs...).HasAll(o.Verbs...) {
-				continue
-			}
-			// filter to resources that belong to the specified categories
-			if len(o.Categories) > 0 && !sets.New[string](resource.Categories...).HasAll(o.Categories...) {
+			if !o.matchesFilters(gv, resource) {
 				continue
 			}
 			resources = append(resources, groupResource{
@@ -324,46 +563,94 @@ func (o *APIResourceOptions) RunAPIResources() error {
 		allResources = append(allResources, apiList)
 	}
 
-	if !*o.PrintFlags.NoHeaders && (o.PrintFlags.OutputFormat == nil || *o.PrintFlags.OutputFormat == "" || *o.PrintFlags.OutputFormat == "wide") {
-		if err = printContextHeaders(w, *o.PrintFlags.OutputFormat); err != nil {
-			return err
+	resources, allResources = o.applyOpenAPIPostProcessing(discoveryClient, resources, allResources)
+
+	return resources, allResources, errs
+}
+
+// filterByOpenAPIMetadata drops resources that don't match the
+// --deprecated/--description-contains flags, which can only be evaluated
+// after enrichWithOpenAPIV3 has run. allResources is rebuilt from the
+// surviving resources so the two stay consistent.
+func filterByOpenAPIMetadata(resources []groupResource, allResources []*metav1.APIResourceList, o *APIResourceOptions) ([]groupResource, []*metav1.APIResourceList) {
+	kept := make([]groupResource, 0, len(resources))
+	keptNames := sets.New[string]()
+	for _, r := range resources {
+		if o.deprecatedChanged && r.Deprecated != o.Deprecated {
+			continue
+		}
+		if o.DescriptionContains != "" && !strings.Contains(r.Description, o.DescriptionContains) {
+			continue
 		}
+		kept = append(kept, r)
+		keptNames.Insert(r.APIGroupVersion + "/" + r.APIResource.Name)
 	}
 
-	flatList := &metav1.APIResourceList{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: allResources[0].APIVersion,
-			Kind:       allResources[0].Kind,
-		},
+	filteredLists := make([]*metav1.APIResourceList, 0, len(allResources))
+	for _, list := range allResources {
+		var apiResources []metav1.APIResource
+		for _, resource := range list.APIResources {
+			if keptNames.Has(list.GroupVersion + "/" + resource.Name) {
+				apiResources = append(apiResources, resource)
+			}
+		}
+		if len(apiResources) == 0 {
+			continue
+		}
+		filteredLists = append(filteredLists, &metav1.APIResourceList{
+			TypeMeta:     list.TypeMeta,
+			GroupVersion: list.GroupVersion,
+			APIResources: apiResources,
+		})
 	}
-	for _, resource := range allResources {
-		flatList.APIResources = append(flatList.APIResources, resource.APIResources...)
+
+	return kept, filteredLists
+}
+
+// RunAPIResources does the work
+func (o *APIResourceOptions) RunAPIResources() error {
+	if len(o.discoveryClients) > 0 {
+		return o.runMultiClusterAPIResources()
 	}
-	//return o.PrintObj(flatList, w)
 
+	w := printers.GetNewTabWriter(o.Out)
+	defer w.Flush()
+
+	resources, allResources, errs := o.fetchGroupResources(o.discoveryClient)
 	sort.Stable(sortableResource{resources, o.SortBy})
-	/*for _, r := range resources {
-		switch *o.PrintFlags.OutputFormat {
-		case "name":
-			name := r.APIResource.Name
-			if len(r.APIGroup) > 0 {
-				name += "." + r.APIGroup
-			}
-			if _, err := fmt.Fprintf(w, "%s\n", name); err != nil {
-				errs = append(errs, err)
+
+	outputFormat := ""
+	if o.PrintFlags.OutputFormat != nil {
+		outputFormat = *o.PrintFlags.OutputFormat
+	}
+
+	switch outputFormat {
+	case "", "wide":
+		// The tab-writer layout is the original, human-readable rendering
+		// of api-resources and remains the fallback for both the default
+		// and -o wide formats.
+		if !*o.PrintFlags.NoHeaders {
+			if err := printContextHeaders(w, outputFormat); err != nil {
+				return err
 			}
-		case "wide":
-			if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%v\t%s\t%v\t%v\n",
-				r.APIResource.Name,
-				strings.Join(r.APIResource.ShortNames, ","),
-				r.APIGroupVersion,
-				r.APIResource.Namespaced,
-				r.APIResource.Kind,
-				strings.Join(r.APIResource.Verbs, ","),
-				strings.Join(r.APIResource.Categories, ",")); err != nil {
-				errs = append(errs, err)
+		}
+		for _, r := range resources {
+			if outputFormat == "wide" {
+				if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%v\t%s\t%v\t%v\t%v\t%s\t%s\n",
+					r.APIResource.Name,
+					strings.Join(r.APIResource.ShortNames, ","),
+					r.APIGroupVersion,
+					r.APIResource.Namespaced,
+					r.APIResource.Kind,
+					strings.Join(r.APIResource.Verbs, ","),
+					strings.Join(r.APIResource.Categories, ","),
+					r.Deprecated,
+					r.Description,
+					strings.Join(r.Subresources, ",")); err != nil {
+					errs = append(errs, err)
+				}
+				continue
 			}
-		case "":
 			if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%v\t%s\n",
 				r.APIResource.Name,
 				strings.Join(r.APIResource.ShortNames, ","),
@@ -373,20 +660,67 @@ func (o *APIResourceOptions) RunAPIResources() error {
 				errs = append(errs, err)
 			}
 		}
-	}*/
+		if len(errs) > 0 {
+			return utilerrors.NewAggregate(errs)
+		}
+		return nil
 
-	/*if len(errs) > 0 {
-		return errors.NewAggregate(errs)
-	}
-	return nil*/
+	case "json", "yaml":
+		if len(allResources) == 0 {
+			return utilerrors.NewAggregate(errs)
+		}
+		flatList := &metav1.APIResourceList{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: allResources[0].APIVersion,
+				Kind:       allResources[0].Kind,
+			},
+		}
+		for _, resource := range allResources {
+			flatList.APIResources = append(flatList.APIResources, resource.APIResources...)
+		}
+		return o.PrintObj(flatList, w)
+
+	case "name":
+		// metav1.APIResourceList has no Items/ObjectMeta, so routing it
+		// through the generic list/name printer machinery doesn't work --
+		// print "<resource>.<group>" per resource directly instead, same
+		// as `kubectl get -o name` does for a resource type rather than an
+		// instance.
+		for _, r := range resources {
+			name := r.APIResource.Name
+			if r.APIGroup != "" {
+				name = fmt.Sprintf("%s.%s", name, r.APIGroup)
+			}
+			if _, err := fmt.Fprintf(w, "%s\n", name); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if len(errs) > 0 {
+			return utilerrors.NewAggregate(errs)
+		}
+		return nil
 
-	return o.PrintObj(flatList, w)
+	default:
+		// custom-columns, jsonpath and go-template formats print one
+		// synthesized APIResource object per resource, so expressions like
+		// -o custom-columns=NAME:.name,GROUP:.apiGroup can address fields
+		// that don't exist on metav1.APIResource itself.
+		for _, r := range resources {
+			if err := o.PrintObj(r.toAPIResource(), w); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if len(errs) > 0 {
+			return utilerrors.NewAggregate(errs)
+		}
+		return nil
+	}
 }
 
 func printContextHeaders(out io.Writer, output string) error {
 	columnNames := []string{"NAME", "SHORTNAMES", "APIVERSION", "NAMESPACED", "KIND"}
 	if output == "wide" {
-		columnNames = append(columnNames, "VERBS", "CATEGORIES")
+		columnNames = append(columnNames, "VERBS", "CATEGORIES", "DEPRECATED", "DESCRIPTION", "SUBRESOURCES")
 	}
 	_, err := fmt.Fprintf(out, "%s\n", strings.Join(columnNames, "\t"))
 	return err
@@ -420,3 +754,302 @@ func (s sortableResource) compareValues(i, j int) (string, string) {
 	}
 	return s.resources[i].APIGroup, s.resources[j].APIGroup
 }
+
+// loadDiscoveryClientsForContexts builds one cached discovery client per
+// named kubeconfig context, using a small bounded worker pool so
+// --all-contexts against a large kubeconfig doesn't open every connection
+// at once.
+func loadDiscoveryClientsForContexts(restClientGetter genericclioptions.RESTClientGetter, contexts []string) (map[string]discovery.CachedDiscoveryInterface, []string, error) {
+	loadingRules, ok := restClientGetter.ToRawKubeConfigLoader().ConfigAccess().(clientcmd.ClientConfigLoader)
+	if !ok {
+		return nil, nil, fmt.Errorf("unable to load kubeconfig for multi-context discovery")
+	}
+
+	type result struct {
+		name   string
+		client discovery.CachedDiscoveryInterface
+		err    error
+	}
+
+	sem := make(chan struct{}, maxContextWorkers)
+	results := make(chan result, len(contexts))
+	var wg sync.WaitGroup
+	for _, name := range contexts {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			overrides := &clientcmd.ConfigOverrides{CurrentContext: name}
+			restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+			if err != nil {
+				results <- result{name: name, err: fmt.Errorf("context %q: %w", name, err)}
+				return
+			}
+			discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+			if err != nil {
+				results <- result{name: name, err: fmt.Errorf("context %q: %w", name, err)}
+				return
+			}
+			results <- result{name: name, client: memory.NewMemCacheClient(discoveryClient)}
+		}(name)
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	clients := make(map[string]discovery.CachedDiscoveryInterface, len(contexts))
+	var errs []error
+	for r := range results {
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		clients[r.name] = r.client
+	}
+	if len(errs) > 0 {
+		return nil, nil, utilerrors.NewAggregate(errs)
+	}
+	return clients, contexts, nil
+}
+
+// gvkKey uniquely identifies a resource across clusters, independent of
+// which version happens to be preferred in any one context. It deliberately
+// excludes the version: the same resource is often preferred at different
+// versions across contexts (e.g. a CRD at v1beta1 in staging, v1 in prod),
+// and that's exactly the kind of difference merge/matrix/diff view are
+// supposed to surface via each groupResource's per-context Contexts map --
+// keying on version here would instead merge it into two disjoint entries.
+func gvkKey(r groupResource) string {
+	return r.APIGroup + "/" + r.APIResource.Name
+}
+
+// runMultiClusterAPIResources queries every selected context and renders the
+// result according to o.MultiClusterView.
+func (o *APIResourceOptions) runMultiClusterAPIResources() error {
+	w := printers.GetNewTabWriter(o.Out)
+	defer w.Flush()
+
+	perContextResources := make(map[string][]groupResource, len(o.contextOrder))
+	perContextLists := make(map[string]*metav1.APIResourceList, len(o.contextOrder))
+	merged := map[string]*groupResource{}
+	var mergedOrder []string
+	var errs []error
+
+	for _, ctxName := range o.contextOrder {
+		discoveryClient, ok := o.discoveryClients[ctxName]
+		if !ok {
+			continue
+		}
+		resources, allResources, fetchErrs := o.fetchGroupResources(discoveryClient)
+		for _, err := range fetchErrs {
+			errs = append(errs, fmt.Errorf("context %q: %w", ctxName, err))
+		}
+		perContextResources[ctxName] = resources
+
+		flat := &metav1.APIResourceList{TypeMeta: metav1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"}}
+		for _, list := range allResources {
+			flat.APIResources = append(flat.APIResources, list.APIResources...)
+		}
+		perContextLists[ctxName] = flat
+
+		for _, r := range resources {
+			key := gvkKey(r)
+			existing, ok := merged[key]
+			if !ok {
+				copyR := r
+				copyR.Contexts = map[string]string{ctxName: r.APIResource.Version}
+				merged[key] = &copyR
+				mergedOrder = append(mergedOrder, key)
+				continue
+			}
+			existing.Contexts[ctxName] = r.APIResource.Version
+		}
+	}
+
+	outputFormat := ""
+	if o.PrintFlags.OutputFormat != nil {
+		outputFormat = *o.PrintFlags.OutputFormat
+	}
+
+	if outputFormat == "json" || outputFormat == "yaml" {
+		list := &MultiClusterAPIResourceList{
+			TypeMeta: metav1.TypeMeta{Kind: "MultiClusterAPIResourceList", APIVersion: "v1"},
+			Contexts: perContextLists,
+		}
+		if err := o.PrintObj(list, w); err != nil {
+			errs = append(errs, err)
+		}
+		if len(errs) > 0 {
+			return utilerrors.NewAggregate(errs)
+		}
+		return nil
+	}
+
+	mergedResources := make([]groupResource, 0, len(mergedOrder))
+	for _, key := range mergedOrder {
+		mergedResources = append(mergedResources, *merged[key])
+	}
+	sort.Stable(sortableResource{mergedResources, o.SortBy})
+
+	switch outputFormat {
+	case "", "wide":
+		var viewErr error
+		switch o.MultiClusterView {
+		case multiClusterViewMerge:
+			viewErr = o.printMergedView(w, mergedResources)
+		case multiClusterViewMatrix:
+			viewErr = o.printMatrixView(w, mergedResources)
+		case multiClusterViewDiff:
+			viewErr = o.printDiffView(w, mergedResources)
+		default:
+			viewErr = fmt.Errorf("unknown --multi-cluster-view: %s", o.MultiClusterView)
+		}
+		if viewErr != nil {
+			errs = append(errs, viewErr)
+		}
+		if len(errs) > 0 {
+			return utilerrors.NewAggregate(errs)
+		}
+		return nil
+
+	case "name":
+		// Same shape as the single-cluster -o name path: one
+		// "<resource>.<group>" line per merged resource, since a
+		// resource type's name doesn't vary by context.
+		for _, r := range mergedResources {
+			name := r.APIResource.Name
+			if r.APIGroup != "" {
+				name = fmt.Sprintf("%s.%s", name, r.APIGroup)
+			}
+			if _, err := fmt.Fprintf(w, "%s\n", name); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if len(errs) > 0 {
+			return utilerrors.NewAggregate(errs)
+		}
+		return nil
+
+	default:
+		// custom-columns, jsonpath and go-template formats print one
+		// synthesized APIResource per merged resource, the same as the
+		// single-cluster path, so expressions like -o
+		// custom-columns=NAME:.name,GROUP:.apiGroup keep working across
+		// --context/--all-contexts instead of silently addressing fields
+		// that don't exist on MultiClusterAPIResourceList.
+		for _, r := range mergedResources {
+			if err := o.PrintObj(r.toAPIResource(), w); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if len(errs) > 0 {
+			return utilerrors.NewAggregate(errs)
+		}
+		return nil
+	}
+}
+
+func (o *APIResourceOptions) printMergedView(w io.Writer, resources []groupResource) error {
+	columnNames := []string{"NAME", "SHORTNAMES", "APIVERSION", "NAMESPACED", "KIND", "CONTEXTS"}
+	if !*o.PrintFlags.NoHeaders {
+		if _, err := fmt.Fprintf(w, "%s\n", strings.Join(columnNames, "\t")); err != nil {
+			return err
+		}
+	}
+	for _, r := range resources {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%v\t%s\t%s\n",
+			r.APIResource.Name,
+			strings.Join(r.APIResource.ShortNames, ","),
+			r.APIGroupVersion,
+			r.APIResource.Namespaced,
+			r.APIResource.Kind,
+			strings.Join(sortedKeys(r.Contexts), ",")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *APIResourceOptions) printMatrixView(w io.Writer, resources []groupResource) error {
+	columnNames := append([]string{"NAME", "APIVERSION", "KIND"}, o.contextOrder...)
+	if !*o.PrintFlags.NoHeaders {
+		if _, err := fmt.Fprintf(w, "%s\n", strings.Join(columnNames, "\t")); err != nil {
+			return err
+		}
+	}
+	for _, r := range resources {
+		row := []string{r.APIResource.Name, r.APIGroupVersion, r.APIResource.Kind}
+		for _, ctxName := range o.contextOrder {
+			if version, ok := r.Contexts[ctxName]; ok {
+				row = append(row, fmt.Sprintf("✓(%s)", version))
+			} else {
+				row = append(row, "✗")
+			}
+		}
+		if _, err := fmt.Fprintf(w, "%s\n", strings.Join(row, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *APIResourceOptions) printDiffView(w io.Writer, resources []groupResource) error {
+	columnNames := append([]string{"NAME", "APIVERSION", "KIND"}, o.contextOrder...)
+	if !*o.PrintFlags.NoHeaders {
+		if _, err := fmt.Fprintf(w, "%s\n", strings.Join(columnNames, "\t")); err != nil {
+			return err
+		}
+	}
+	for _, r := range resources {
+		if !hasAvailabilityOrVersionDiff(r, o.contextOrder) {
+			continue
+		}
+		row := []string{r.APIResource.Name, r.APIGroupVersion, r.APIResource.Kind}
+		for _, ctxName := range o.contextOrder {
+			if version, ok := r.Contexts[ctxName]; ok {
+				row = append(row, version)
+			} else {
+				row = append(row, "-")
+			}
+		}
+		if _, err := fmt.Fprintf(w, "%s\n", strings.Join(row, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasAvailabilityOrVersionDiff reports whether r is not present in every
+// context, or is present with differing served versions across contexts.
+func hasAvailabilityOrVersionDiff(r groupResource, contexts []string) bool {
+	if len(r.Contexts) != len(contexts) {
+		return true
+	}
+	var first string
+	for i, ctxName := range contexts {
+		version, ok := r.Contexts[ctxName]
+		if !ok {
+			return true
+		}
+		if i == 0 {
+			first = version
+			continue
+		}
+		if version != first {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
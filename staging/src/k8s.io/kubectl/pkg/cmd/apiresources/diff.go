@@ -0,0 +1,234 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiresources
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var diffExample = templates.Examples(`
+	# Compare two saved snapshots
+	kubectl api-resources diff before.json after.json
+
+	# Compare a saved snapshot against the live cluster
+	kubectl api-resources diff before.json`)
+
+// DiffOptions holds the data required to run "api-resources diff".
+type DiffOptions struct {
+	APIResourceOptions *APIResourceOptions
+	FileA              string
+	FileB              string
+
+	genericiooptions.IOStreams
+}
+
+// NewDiffOptions creates the options for "api-resources diff".
+func NewDiffOptions(ioStreams genericiooptions.IOStreams) *DiffOptions {
+	return &DiffOptions{
+		APIResourceOptions: NewAPIResourceOptions(ioStreams),
+		IOStreams:          ioStreams,
+	}
+}
+
+// NewCmdAPIResourcesDiff creates the "api-resources diff" subcommand.
+func NewCmdAPIResourcesDiff(restClientGetter genericclioptions.RESTClientGetter, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewDiffOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "diff FILE_A [FILE_B]",
+		Short:   i18n.T("Compare two API resource snapshots, or a snapshot against the live cluster"),
+		Long:    i18n.T("Compare two API resource snapshots taken with \"api-resources snapshot\", or a single snapshot against the live cluster if FILE_B is omitted. Exits non-zero if there are differences, so it can be used in CI to catch API surface regressions across cluster upgrades."),
+		Example: diffExample,
+		Args:    cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(restClientGetter, cmd, args))
+			cmdutil.CheckErr(o.Run())
+		},
+	}
+
+	// These only affect a live-cluster comparison (FILE_B omitted); a
+	// diff between two saved snapshots always covers what was captured.
+	cmd.Flags().StringVar(&o.APIResourceOptions.APIGroup, "api-group", o.APIResourceOptions.APIGroup, "When comparing against the live cluster, limit to resources in the specified API group.")
+	cmd.Flags().BoolVar(&o.APIResourceOptions.Cached, "cached", o.APIResourceOptions.Cached, "When comparing against the live cluster, use the cached list of resources if available.")
+
+	return cmd
+}
+
+// Complete adapts from the command line args and validates them
+func (o *DiffOptions) Complete(restClientGetter genericclioptions.RESTClientGetter, cmd *cobra.Command, args []string) error {
+	o.FileA = args[0]
+	if len(args) == 2 {
+		o.FileB = args[1]
+	}
+	return o.APIResourceOptions.Complete(restClientGetter, cmd, []string{})
+}
+
+// resourceDiff describes how a single GVK differs between two snapshots.
+type resourceDiff struct {
+	key     string
+	kind    string // "added", "removed", or "changed"
+	details []string
+}
+
+// Run compares the two resource sets and prints any differences. It returns
+// a non-nil error when differences are found, so that CI usage of
+// "api-resources diff" fails the build.
+func (o *DiffOptions) Run() error {
+	listA, err := loadSnapshotResources(o.FileA)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", o.FileA, err)
+	}
+
+	var listB *metav1.APIResourceList
+	if len(o.FileB) > 0 {
+		listB, err = loadSnapshotResources(o.FileB)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", o.FileB, err)
+		}
+	} else {
+		_, allResources, errs := o.APIResourceOptions.fetchGroupResources(o.APIResourceOptions.discoveryClient)
+		if len(allResources) == 0 && len(errs) > 0 {
+			return utilerrors.NewAggregate(errs)
+		}
+		listB = flattenAPIResourceLists(allResources)
+	}
+
+	diffs := diffAPIResourceLists(listA, listB)
+	if len(diffs) == 0 {
+		fmt.Fprintln(o.Out, "No differences found.")
+		return nil
+	}
+
+	for _, d := range diffs {
+		fmt.Fprintf(o.Out, "%s %s: %s\n", strings.ToUpper(d.kind), d.key, strings.Join(d.details, "; "))
+	}
+	return fmt.Errorf("found %d difference(s) between %s and %s", len(diffs), o.FileA, describeFileB(o.FileB))
+}
+
+func describeFileB(fileB string) string {
+	if len(fileB) == 0 {
+		return "the live cluster"
+	}
+	return fileB
+}
+
+// loadSnapshotResources reads an APIResourceSnapshot file and returns its
+// Resources list.
+func loadSnapshotResources(path string) (*metav1.APIResourceList, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &APIResourceSnapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, err
+	}
+	if snapshot.Resources == nil {
+		return &metav1.APIResourceList{}, nil
+	}
+	return snapshot.Resources, nil
+}
+
+// resourceKey uniquely identifies a resource across API groups.
+func resourceKey(group, version, name string) string {
+	if len(group) == 0 {
+		return fmt.Sprintf("%s/%s", version, name)
+	}
+	return fmt.Sprintf("%s/%s/%s", group, version, name)
+}
+
+// diffAPIResourceLists reports every resource that was added, removed, or
+// changed (verbs, namespaced-ness, categories, short names) between a and b.
+func diffAPIResourceLists(a, b *metav1.APIResourceList) []resourceDiff {
+	byKeyA := indexAPIResources(a)
+	byKeyB := indexAPIResources(b)
+
+	var diffs []resourceDiff
+	for key, resB := range byKeyB {
+		resA, existed := byKeyA[key]
+		if !existed {
+			diffs = append(diffs, resourceDiff{key: key, kind: "added"})
+			continue
+		}
+		if details := compareAPIResources(resA, resB); len(details) > 0 {
+			diffs = append(diffs, resourceDiff{key: key, kind: "changed", details: details})
+		}
+	}
+	for key := range byKeyA {
+		if _, stillExists := byKeyB[key]; !stillExists {
+			diffs = append(diffs, resourceDiff{key: key, kind: "removed"})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].key < diffs[j].key })
+	return diffs
+}
+
+func indexAPIResources(list *metav1.APIResourceList) map[string]metav1.APIResource {
+	index := make(map[string]metav1.APIResource, len(list.APIResources))
+	for _, r := range list.APIResources {
+		index[resourceKey(r.Group, r.Version, r.Name)] = r
+	}
+	return index
+}
+
+func compareAPIResources(a, b metav1.APIResource) []string {
+	var details []string
+	if a.Namespaced != b.Namespaced {
+		details = append(details, fmt.Sprintf("namespaced: %v -> %v", a.Namespaced, b.Namespaced))
+	}
+	if !stringSlicesEqualUnordered(a.Verbs, b.Verbs) {
+		details = append(details, fmt.Sprintf("verbs: %v -> %v", a.Verbs, b.Verbs))
+	}
+	if !stringSlicesEqualUnordered(a.Categories, b.Categories) {
+		details = append(details, fmt.Sprintf("categories: %v -> %v", a.Categories, b.Categories))
+	}
+	if !stringSlicesEqualUnordered(a.ShortNames, b.ShortNames) {
+		details = append(details, fmt.Sprintf("shortNames: %v -> %v", a.ShortNames, b.ShortNames))
+	}
+	return details
+}
+
+func stringSlicesEqualUnordered(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]string(nil), a...)
+	sortedB := append([]string(nil), b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
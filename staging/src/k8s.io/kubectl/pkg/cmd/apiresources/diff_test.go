@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiresources
+
+import (
+	"sort"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func apiResourceList(resources ...metav1.APIResource) *metav1.APIResourceList {
+	return &metav1.APIResourceList{APIResources: resources}
+}
+
+func TestDiffAPIResourceLists(t *testing.T) {
+	a := apiResourceList(
+		metav1.APIResource{Name: "pods", Version: "v1", Namespaced: true, Verbs: []string{"get", "list"}},
+		metav1.APIResource{Name: "ingresses", Group: "networking.k8s.io", Version: "v1", Namespaced: true},
+	)
+	b := apiResourceList(
+		// changed: namespaced flipped and a verb dropped
+		metav1.APIResource{Name: "pods", Version: "v1", Namespaced: false, Verbs: []string{"get"}},
+		// added
+		metav1.APIResource{Name: "deployments", Group: "apps", Version: "v1", Namespaced: true},
+		// "ingresses" removed
+	)
+
+	diffs := diffAPIResourceLists(a, b)
+
+	got := map[string]string{}
+	for _, d := range diffs {
+		got[d.key] = d.kind
+	}
+	want := map[string]string{
+		"v1/pods":                        "changed",
+		"apps/v1/deployments":            "added",
+		"networking.k8s.io/v1/ingresses": "removed",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d diffs %v, want %d diffs %v", len(got), got, len(want), want)
+	}
+	for key, wantKind := range want {
+		if gotKind, ok := got[key]; !ok || gotKind != wantKind {
+			t.Errorf("diff for %s = %q, want %q", key, gotKind, wantKind)
+		}
+	}
+}
+
+func TestDiffAPIResourceListsNoChanges(t *testing.T) {
+	list := apiResourceList(metav1.APIResource{Name: "pods", Version: "v1", Namespaced: true, Verbs: []string{"get"}})
+	if diffs := diffAPIResourceLists(list, list); len(diffs) != 0 {
+		t.Errorf("diffAPIResourceLists(list, list) = %v, want no diffs", diffs)
+	}
+}
+
+func TestCompareAPIResources(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b metav1.APIResource
+		want int // number of detail lines expected
+	}{
+		{
+			name: "identical",
+			a:    metav1.APIResource{Namespaced: true, Verbs: []string{"get"}, Categories: []string{"all"}, ShortNames: []string{"po"}},
+			b:    metav1.APIResource{Namespaced: true, Verbs: []string{"get"}, Categories: []string{"all"}, ShortNames: []string{"po"}},
+			want: 0,
+		},
+		{
+			name: "namespaced changed",
+			a:    metav1.APIResource{Namespaced: true},
+			b:    metav1.APIResource{Namespaced: false},
+			want: 1,
+		},
+		{
+			name: "verbs reordered only -- not a change",
+			a:    metav1.APIResource{Verbs: []string{"get", "list"}},
+			b:    metav1.APIResource{Verbs: []string{"list", "get"}},
+			want: 0,
+		},
+		{
+			name: "verbs, categories and shortNames all changed",
+			a:    metav1.APIResource{Verbs: []string{"get"}, Categories: []string{"all"}, ShortNames: []string{"po"}},
+			b:    metav1.APIResource{Verbs: []string{"get", "list"}, Categories: []string{"core"}, ShortNames: nil},
+			want: 3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := compareAPIResources(tc.a, tc.b); len(got) != tc.want {
+				t.Errorf("compareAPIResources() = %v, want %d detail(s)", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStringSlicesEqualUnordered(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want bool
+	}{
+		{name: "both empty", a: nil, b: nil, want: true},
+		{name: "same order", a: []string{"a", "b"}, b: []string{"a", "b"}, want: true},
+		{name: "different order", a: []string{"a", "b"}, b: []string{"b", "a"}, want: true},
+		{name: "different length", a: []string{"a"}, b: []string{"a", "b"}, want: false},
+		{name: "different contents", a: []string{"a", "b"}, b: []string{"a", "c"}, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := stringSlicesEqualUnordered(tc.a, tc.b); got != tc.want {
+				t.Errorf("stringSlicesEqualUnordered(%v, %v) = %v, want %v", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResourceKey(t *testing.T) {
+	tests := []struct {
+		group, version, name string
+		want                 string
+	}{
+		{group: "", version: "v1", name: "pods", want: "v1/pods"},
+		{group: "apps", version: "v1", name: "deployments", want: "apps/v1/deployments"},
+	}
+	for _, tc := range tests {
+		if got := resourceKey(tc.group, tc.version, tc.name); got != tc.want {
+			t.Errorf("resourceKey(%q, %q, %q) = %q, want %q", tc.group, tc.version, tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestDiffAPIResourceListsSortedByKey(t *testing.T) {
+	a := apiResourceList()
+	b := apiResourceList(
+		metav1.APIResource{Name: "zeta", Version: "v1"},
+		metav1.APIResource{Name: "alpha", Version: "v1"},
+	)
+
+	diffs := diffAPIResourceLists(a, b)
+	keys := make([]string, len(diffs))
+	for i, d := range diffs {
+		keys[i] = d.key
+	}
+	if !sort.StringsAreSorted(keys) {
+		t.Errorf("diffAPIResourceLists() keys = %v, want sorted", keys)
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiresources
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/openapi3"
+	"k8s.io/kube-openapi/pkg/spec3"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// gvkExtension is the shape of the "x-kubernetes-group-version-kind"
+// vendor extension that OpenAPI v3 schemas carry to identify which GVK they
+// describe.
+type gvkExtension struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+// enrichWithOpenAPIV3 fills in Description, Deprecated, DeprecationVersion
+// and FieldCount on each of resources by looking up the matching schema in
+// the server's OpenAPI v3 documents. It is best-effort: servers that don't
+// serve OpenAPI v3, or GroupVersions with no schema, are skipped rather than
+// treated as an error, since api-resources should keep working against
+// older or partial servers.
+func enrichWithOpenAPIV3(discoveryClient discovery.CachedDiscoveryInterface, resources []groupResource) {
+	root := openapi3.NewRoot(discoveryClient.OpenAPIV3())
+	specsByGV := map[schema.GroupVersion]*spec3.OpenAPI{}
+
+	for i := range resources {
+		r := &resources[i]
+		gv, err := schema.ParseGroupVersion(r.APIGroupVersion)
+		if err != nil {
+			continue
+		}
+
+		gvSpec, cached := specsByGV[gv]
+		if !cached {
+			gvSpec, err = root.GVSpec(gv)
+			if err != nil {
+				gvSpec = nil
+			}
+			specsByGV[gv] = gvSpec
+		}
+		if gvSpec == nil || gvSpec.Components == nil {
+			continue
+		}
+
+		schemaObj := findSchemaForGVK(gvSpec.Components.Schemas, gv.Group, gv.Version, r.APIResource.Kind)
+		if schemaObj == nil {
+			continue
+		}
+
+		r.Description = shortDescription(schemaObj.Description)
+		// A resource counts as deprecated if either the schema itself says
+		// so via the native OpenAPI v3 "deprecated" keyword, or the server
+		// tags it with the "x-kubernetes-deprecated" vendor extension --
+		// some API servers only set one or the other.
+		r.Deprecated = schemaObj.Deprecated
+		if deprecated, ok := schemaObj.Extensions.GetBool("x-kubernetes-deprecated"); ok {
+			r.Deprecated = r.Deprecated || deprecated
+		}
+		if version, ok := schemaObj.Extensions.GetString("x-kubernetes-deprecated-version"); ok {
+			r.DeprecationVersion = version
+		}
+		r.FieldCount = countFields(schemaObj, gvSpec.Components.Schemas, map[string]bool{})
+	}
+}
+
+// findSchemaForGVK looks through a Components.Schemas map for the schema
+// tagged with the given group/version/kind via the
+// "x-kubernetes-group-version-kind" extension.
+func findSchemaForGVK(schemas map[string]*spec.Schema, group, version, kind string) *spec.Schema {
+	for _, s := range schemas {
+		if s == nil {
+			continue
+		}
+		var gvks []gvkExtension
+		if err := s.Extensions.GetObject("x-kubernetes-group-version-kind", &gvks); err != nil {
+			continue
+		}
+		for _, gvk := range gvks {
+			if gvk.Group == group && gvk.Version == version && gvk.Kind == kind {
+				return s
+			}
+		}
+	}
+	return nil
+}
+
+// shortDescription returns just the first sentence (or line) of a schema
+// description, so wide-output columns stay readable.
+func shortDescription(description string) string {
+	description = strings.TrimSpace(strings.SplitN(description, "\n", 2)[0])
+	if idx := strings.Index(description, ". "); idx != -1 {
+		description = description[:idx+1]
+	}
+	return description
+}
+
+// countFields walks a schema's properties tree, following local $ref's, and
+// returns the total number of fields reachable from it. seen guards against
+// infinite recursion on self-referential schemas (e.g. RawExtension-style
+// unions). Each property gets its own copy of seen, so a cycle is only
+// suppressed along the path that produces it -- two unrelated properties
+// that happen to reference the same schema (e.g. two fields both typed
+// ObjectMeta) are each still fully counted.
+func countFields(s *spec.Schema, schemas map[string]*spec.Schema, seen map[string]bool) int {
+	if s == nil {
+		return 0
+	}
+
+	count := 0
+	for _, prop := range s.Properties {
+		count++
+		branchSeen := copySeen(seen)
+		count += countFields(resolveRef(&prop, schemas, branchSeen), schemas, branchSeen)
+	}
+	return count
+}
+
+// resolveRef follows a schema's $ref (if any) to the referenced schema in
+// schemas, guarding against cycles via seen.
+func resolveRef(s *spec.Schema, schemas map[string]*spec.Schema, seen map[string]bool) *spec.Schema {
+	if s == nil || s.Ref.String() == "" {
+		return s
+	}
+	ref := s.Ref.String()
+	if seen[ref] {
+		return nil
+	}
+	seen[ref] = true
+
+	const prefix = "#/components/schemas/"
+	if !strings.HasPrefix(ref, prefix) {
+		return nil
+	}
+	return schemas[strings.TrimPrefix(ref, prefix)]
+}
+
+// copySeen returns an independent copy of seen so sibling branches of the
+// countFields walk don't share cycle-detection state.
+func copySeen(seen map[string]bool) map[string]bool {
+	cp := make(map[string]bool, len(seen))
+	for k, v := range seen {
+		cp[k] = v
+	}
+	return cp
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiresources
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var snapshotExample = templates.Examples(`
+	# Save the cluster's current API resources to a file
+	kubectl api-resources snapshot -o api-surface.json
+
+	# Save only the resources in a specific API group
+	kubectl api-resources snapshot --api-group=apps -o apps-surface.json`)
+
+// APIResourceSnapshot is the on-disk format written by "api-resources
+// snapshot" and read back by "api-resources diff". It is deliberately
+// small and stable so that snapshots taken with different kubectl versions
+// remain comparable.
+type APIResourceSnapshot struct {
+	metav1.TypeMeta `json:",inline"`
+
+	ServerVersion string                  `json:"serverVersion"`
+	Timestamp     time.Time               `json:"timestamp"`
+	Resources     *metav1.APIResourceList `json:"resources"`
+}
+
+// SnapshotOptions holds the data required to run "api-resources snapshot".
+type SnapshotOptions struct {
+	APIResourceOptions *APIResourceOptions
+	OutputFile         string
+
+	genericiooptions.IOStreams
+}
+
+// NewSnapshotOptions creates the options for "api-resources snapshot".
+func NewSnapshotOptions(ioStreams genericiooptions.IOStreams) *SnapshotOptions {
+	return &SnapshotOptions{
+		APIResourceOptions: NewAPIResourceOptions(ioStreams),
+		IOStreams:          ioStreams,
+	}
+}
+
+// NewCmdAPIResourcesSnapshot creates the "api-resources snapshot" subcommand.
+func NewCmdAPIResourcesSnapshot(restClientGetter genericclioptions.RESTClientGetter, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewSnapshotOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "snapshot -o FILE",
+		Short:   i18n.T("Save the server's current API resources to a file"),
+		Long:    i18n.T("Save the server's current API resources, along with the server version and a timestamp, to a JSON file for later comparison with \"api-resources diff\"."),
+		Example: snapshotExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(restClientGetter, cmd, args))
+			cmdutil.CheckErr(o.Validate())
+			cmdutil.CheckErr(o.Run())
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.OutputFile, "output", "o", o.OutputFile, "File to write the snapshot to.")
+	cmd.Flags().StringVar(&o.APIResourceOptions.APIGroup, "api-group", o.APIResourceOptions.APIGroup, "Limit to resources in the specified API group.")
+	cmd.Flags().BoolVar(&o.APIResourceOptions.Cached, "cached", o.APIResourceOptions.Cached, "Use the cached list of resources if available.")
+	return cmd
+}
+
+// Complete adapts from the command line args and validates them
+func (o *SnapshotOptions) Complete(restClientGetter genericclioptions.RESTClientGetter, cmd *cobra.Command, args []string) error {
+	if len(args) != 0 {
+		return cmdutil.UsageErrorf(cmd, "unexpected arguments: %v", args)
+	}
+	return o.APIResourceOptions.Complete(restClientGetter, cmd, args)
+}
+
+// Validate checks that SnapshotOptions has sufficient information to run
+func (o *SnapshotOptions) Validate() error {
+	if len(o.OutputFile) == 0 {
+		return fmt.Errorf("--output is required")
+	}
+	return o.APIResourceOptions.Validate()
+}
+
+// Run fetches the server's current API resources and writes a snapshot file
+func (o *SnapshotOptions) Run() error {
+	_, allResources, errs := o.APIResourceOptions.fetchGroupResources(o.APIResourceOptions.discoveryClient)
+	if len(allResources) == 0 && len(errs) > 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+
+	flatList := flattenAPIResourceLists(allResources)
+
+	serverVersion, err := o.APIResourceOptions.discoveryClient.ServerVersion()
+	if err != nil {
+		return err
+	}
+
+	snapshot := &APIResourceSnapshot{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "APIResourceSnapshot",
+			APIVersion: "v1",
+		},
+		ServerVersion: serverVersion.GitVersion,
+		Timestamp:     time.Now().UTC(),
+		Resources:     flatList,
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	return os.WriteFile(o.OutputFile, data, 0644)
+}
+
+// flattenAPIResourceLists merges a set of per-GroupVersion APIResourceLists
+// into a single list, suitable for snapshotting or diffing. Since the
+// resulting list no longer groups resources by GroupVersion, each
+// APIResource's Group/Version fields are stamped with the GroupVersion of
+// the list it came from so identity isn't lost.
+func flattenAPIResourceLists(allResources []*metav1.APIResourceList) *metav1.APIResourceList {
+	flatList := &metav1.APIResourceList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "APIResourceList",
+			APIVersion: "v1",
+		},
+	}
+	for _, list := range allResources {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, resource := range list.APIResources {
+			if len(resource.Group) == 0 {
+				resource.Group = gv.Group
+			}
+			if len(resource.Version) == 0 {
+				resource.Version = gv.Version
+			}
+			flatList.APIResources = append(flatList.APIResources, resource)
+		}
+	}
+	return flatList
+}